@@ -0,0 +1,27 @@
+package calculator
+
+import "math/big"
+
+// AddRat returns the exact sum of a and b.
+func AddRat(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Add(a, b)
+}
+
+// SubRat returns the exact difference of a and b.
+func SubRat(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Sub(a, b)
+}
+
+// MulRat returns the exact product of a and b.
+func MulRat(a, b *big.Rat) *big.Rat {
+	return new(big.Rat).Mul(a, b)
+}
+
+// DivRat returns the exact quotient of a and b, or ErrDivByZero if b is
+// zero.
+func DivRat(a, b *big.Rat) (*big.Rat, error) {
+	if b.Sign() == 0 {
+		return nil, ErrDivByZero
+	}
+	return new(big.Rat).Quo(a, b), nil
+}
@@ -0,0 +1,71 @@
+package calculator
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func TestFloatDivergesFromRational(t *testing.T) {
+	floatResult, err := Evaluate("0.1 + 0.2")
+	if err != nil {
+		t.Fatalf("Evaluate returned error: %v", err)
+	}
+	if floatResult == 0.3 {
+		t.Fatalf("expected float64 0.1 + 0.2 to diverge from 0.3, got exactly %v", floatResult)
+	}
+
+	ctx := NewContext()
+	ctx.SetMode(ModeRational)
+	n, err := EvaluateNumber("0.1 + 0.2", ctx)
+	if err != nil {
+		t.Fatalf("EvaluateNumber returned error: %v", err)
+	}
+	want := big.NewRat(3, 10)
+	if n.Rat().Cmp(want) != 0 {
+		t.Errorf("rational 0.1 + 0.2 = %v, want %v", n.Rat(), want)
+	}
+}
+
+func TestRationalRepeatingDecimal(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetMode(ModeRational)
+
+	n, err := EvaluateNumber("1/3 + 1/3 + 1/3", ctx)
+	if err != nil {
+		t.Fatalf("EvaluateNumber returned error: %v", err)
+	}
+	if n.Rat().Cmp(big.NewRat(1, 1)) != 0 {
+		t.Errorf("1/3 + 1/3 + 1/3 = %v, want 1", n.Rat())
+	}
+}
+
+func TestRatDivByZero(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetMode(ModeRational)
+
+	_, err := EvaluateNumber("1 / 0", ctx)
+	if !errors.Is(err, ErrDivByZero) {
+		t.Errorf("EvaluateNumber(1/0) error = %v, want ErrDivByZero", err)
+	}
+}
+
+func TestBigFloatMode(t *testing.T) {
+	ctx := NewContext()
+	ctx.SetMode(ModeBigFloat)
+
+	n, err := EvaluateNumber("1 + 2 * 3", ctx)
+	if err != nil {
+		t.Fatalf("EvaluateNumber returned error: %v", err)
+	}
+	if n.Float64() != 7 {
+		t.Errorf("1 + 2 * 3 = %v, want 7", n.Float64())
+	}
+}
+
+func TestDivRatByZero(t *testing.T) {
+	_, err := DivRat(big.NewRat(1, 1), big.NewRat(0, 1))
+	if !errors.Is(err, ErrDivByZero) {
+		t.Errorf("DivRat(1, 0) error = %v, want ErrDivByZero", err)
+	}
+}
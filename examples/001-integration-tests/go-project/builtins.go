@@ -0,0 +1,37 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// registerBuiltins populates ctx with the standard function set: sqrt,
+// pow, abs, min, max and negative.
+func registerBuiltins(ctx *Context) {
+	ctx.Register("sqrt", 1, func(args ...float64) (float64, error) {
+		if args[0] < 0 {
+			return 0, fmt.Errorf("%w: sqrt of negative number %v", ErrUnexpectedToken, args[0])
+		}
+		return math.Sqrt(args[0]), nil
+	})
+
+	ctx.Register("pow", 2, func(args ...float64) (float64, error) {
+		return math.Pow(args[0], args[1]), nil
+	})
+
+	ctx.Register("abs", 1, func(args ...float64) (float64, error) {
+		return math.Abs(args[0]), nil
+	})
+
+	ctx.Register("min", 2, func(args ...float64) (float64, error) {
+		return math.Min(args[0], args[1]), nil
+	})
+
+	ctx.Register("max", 2, func(args ...float64) (float64, error) {
+		return math.Max(args[0], args[1]), nil
+	})
+
+	ctx.Register("negative", 1, func(args ...float64) (float64, error) {
+		return Subtract(0, args[0]), nil
+	})
+}
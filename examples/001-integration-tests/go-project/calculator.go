@@ -3,18 +3,18 @@ package calculator
 
 import "errors"
 
-// Add returns the sum of two integers.
-func Add(a, b int) int {
+// Add returns the sum of two numbers.
+func Add(a, b float64) float64 {
 	return a + b
 }
 
-// Subtract returns the difference between two integers.
-func Subtract(a, b int) int {
+// Subtract returns the difference between two numbers.
+func Subtract(a, b float64) float64 {
 	return a - b
 }
 
-// Multiply returns the product of two integers.
-func Multiply(a, b int) int {
+// Multiply returns the product of two numbers.
+func Multiply(a, b float64) float64 {
 	return a * b
 }
 
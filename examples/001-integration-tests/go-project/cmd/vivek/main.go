@@ -0,0 +1,144 @@
+// Command vivek is a small CLI and REPL front end for the calculator
+// package. Run with flags for a one-shot calculation, or with no flags
+// to start an interactive session.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	calculator "github.com/sanketn26/vivek/examples/001-integration-tests/go-project"
+)
+
+// assignRe recognizes an assignment line like "x = 2+3", capturing the
+// target name ("x") and the right-hand side ("2+3").
+var assignRe = regexp.MustCompile(`^([a-zA-Z_]\w*)\s*=\s*(.+)$`)
+
+func main() {
+	if err := Run(os.Stdin, os.Stdout, os.Args[1:]); err != nil {
+		fmt.Fprintln(os.Stderr, "error:", err)
+		os.Exit(1)
+	}
+}
+
+// Run is the entry point used by both main and tests. It dispatches to
+// one-shot flag mode when args are non-empty, or to the interactive REPL
+// otherwise, reading from in and writing all output to out.
+func Run(in io.Reader, out io.Writer, args []string) error {
+	if len(args) > 0 {
+		return runFlags(out, args)
+	}
+	return runREPL(in, out)
+}
+
+// runFlags handles "-add 3 5", "-sub 3 5", "-mul 3 5", "-div 3 5" and
+// "-expr <expr>".
+func runFlags(out io.Writer, args []string) error {
+	switch args[0] {
+	case "-add", "-sub", "-mul", "-div":
+		if len(args) != 3 {
+			return fmt.Errorf("%s requires two operands", args[0])
+		}
+		a, err := strconv.ParseFloat(args[1], 64)
+		if err != nil {
+			return fmt.Errorf("invalid operand %q: %w", args[1], err)
+		}
+		b, err := strconv.ParseFloat(args[2], 64)
+		if err != nil {
+			return fmt.Errorf("invalid operand %q: %w", args[2], err)
+		}
+
+		var result float64
+		switch args[0] {
+		case "-add":
+			result = calculator.Add(a, b)
+		case "-sub":
+			result = calculator.Subtract(a, b)
+		case "-mul":
+			result = calculator.Multiply(a, b)
+		case "-div":
+			result, err = calculator.Divide(a, b)
+			if err != nil {
+				return err
+			}
+		}
+		fmt.Fprintln(out, formatResult(result))
+		return nil
+
+	case "-expr":
+		if len(args) != 2 {
+			return fmt.Errorf("-expr requires exactly one expression argument")
+		}
+		result, err := calculator.Evaluate(args[1])
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(out, formatResult(result))
+		return nil
+
+	default:
+		return fmt.Errorf("unrecognized flag %q", args[0])
+	}
+}
+
+// runREPL drives an interactive session: one expression, assignment, or
+// command per line, until ":quit" or EOF. Assignments and later
+// references are resolved through a single calculator.Context, so
+// variables, pi/e and the built-in functions are all available.
+func runREPL(in io.Reader, out io.Writer) error {
+	scanner := bufio.NewScanner(in)
+	ctx := calculator.NewContext()
+	var history []string
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		switch {
+		case line == ":quit":
+			return nil
+
+		case line == "history":
+			for i, h := range history {
+				fmt.Fprintf(out, "%d: %s\n", i+1, h)
+			}
+
+		default:
+			history = append(history, line)
+
+			if m := assignRe.FindStringSubmatch(line); m != nil {
+				name, rhs := m[1], m[2]
+				result, err := calculator.EvaluateWith(rhs, ctx)
+				if err != nil {
+					fmt.Fprintln(out, "error:", err)
+					continue
+				}
+				ctx.Set(name, result)
+				fmt.Fprintln(out, formatResult(result))
+				continue
+			}
+
+			result, err := calculator.EvaluateWith(line, ctx)
+			if err != nil {
+				fmt.Fprintln(out, "error:", err)
+				continue
+			}
+			fmt.Fprintln(out, formatResult(result))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// formatResult renders a float64 without a trailing ".0" for whole
+// numbers, matching what a user typing "3 + 5" expects to see.
+func formatResult(v float64) string {
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -0,0 +1,61 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRunFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"add", []string{"-add", "3", "5"}, "8\n"},
+		{"sub", []string{"-sub", "10", "4"}, "6\n"},
+		{"mul", []string{"-mul", "3", "5"}, "15\n"},
+		{"div", []string{"-div", "10", "4"}, "2.5\n"},
+		{"expr", []string{"-expr", "1 + 2 * 3"}, "7\n"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := Run(strings.NewReader(""), &out, tt.args); err != nil {
+				t.Fatalf("Run(%v) returned error: %v", tt.args, err)
+			}
+			if out.String() != tt.want {
+				t.Errorf("Run(%v) wrote %q, want %q", tt.args, out.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestRunFlagsDivByZero(t *testing.T) {
+	var out bytes.Buffer
+	err := Run(strings.NewReader(""), &out, []string{"-div", "1", "0"})
+	if err == nil {
+		t.Fatal("Run(-div 1 0) returned no error")
+	}
+}
+
+func TestRunREPL(t *testing.T) {
+	in := strings.NewReader("1 + 2\nx = 2 + 3\nx * 4\nhistory\n:quit\nshould not run\n")
+	var out bytes.Buffer
+
+	if err := Run(in, &out, nil); err != nil {
+		t.Fatalf("Run(REPL) returned error: %v", err)
+	}
+
+	got := out.String()
+	wantLines := []string{"3", "5", "20", "1: 1 + 2", "2: x = 2 + 3", "3: x * 4"}
+	for _, want := range wantLines {
+		if !strings.Contains(got, want) {
+			t.Errorf("REPL output %q missing expected line %q", got, want)
+		}
+	}
+	if strings.Contains(got, "should not run") {
+		t.Errorf("REPL kept processing input after :quit")
+	}
+}
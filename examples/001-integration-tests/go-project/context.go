@@ -0,0 +1,127 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// Fn is a callable exposed to expressions evaluated with a Context. It
+// receives exactly as many arguments as the arity it was registered
+// with.
+type Fn func(args ...float64) (float64, error)
+
+type registeredFn struct {
+	arity int
+	fn    Fn
+}
+
+// Mode selects which arithmetic backend EvaluateNumber uses.
+type Mode int
+
+const (
+	// ModeFloat64 evaluates using float64 arithmetic (the default).
+	ModeFloat64 Mode = iota
+	// ModeRational evaluates using exact big.Rat arithmetic.
+	ModeRational
+	// ModeBigFloat evaluates using big.Float arithmetic at Precision bits.
+	ModeBigFloat
+)
+
+// defaultPrecision is the big.Float precision, in bits, used when a
+// Context hasn't had SetPrecision called on it.
+const defaultPrecision = 256
+
+// Context holds the named constants, user variables, and callable
+// functions visible to EvaluateWith, plus the arithmetic Mode used by
+// EvaluateNumber. The zero value is usable but has no built-ins; use
+// NewContext for a context pre-populated with pi, e, and the standard
+// function set.
+type Context struct {
+	vars      map[string]float64
+	funcs     map[string]registeredFn
+	mode      Mode
+	precision uint
+}
+
+// NewContext returns a Context seeded with the constants "pi" and "e",
+// and the built-in functions sqrt, pow, abs, min, max and negative. Its
+// Mode defaults to ModeFloat64.
+func NewContext() *Context {
+	ctx := &Context{
+		vars:      map[string]float64{"pi": math.Pi, "e": math.E},
+		funcs:     map[string]registeredFn{},
+		precision: defaultPrecision,
+	}
+	registerBuiltins(ctx)
+	return ctx
+}
+
+// Mode returns the arithmetic backend EvaluateNumber currently uses.
+func (c *Context) Mode() Mode {
+	return c.mode
+}
+
+// SetMode selects the arithmetic backend EvaluateNumber uses.
+func (c *Context) SetMode(mode Mode) {
+	c.mode = mode
+}
+
+// Precision returns the big.Float precision, in bits, used in
+// ModeBigFloat.
+func (c *Context) Precision() uint {
+	if c.precision == 0 {
+		return defaultPrecision
+	}
+	return c.precision
+}
+
+// SetPrecision sets the big.Float precision, in bits, used in
+// ModeBigFloat.
+func (c *Context) SetPrecision(bits uint) {
+	c.precision = bits
+}
+
+// Set assigns value to the named variable, overwriting any existing
+// value or constant of that name.
+func (c *Context) Set(name string, value float64) {
+	if c.vars == nil {
+		c.vars = map[string]float64{}
+	}
+	c.vars[name] = value
+}
+
+// Register adds a callable function under name, which must be called
+// with exactly arity arguments. Registering a name that already exists
+// replaces it.
+func (c *Context) Register(name string, arity int, fn Fn) {
+	if c.funcs == nil {
+		c.funcs = map[string]registeredFn{}
+	}
+	c.funcs[name] = registeredFn{arity: arity, fn: fn}
+}
+
+// lookupVar returns the value bound to name, if any.
+func (c *Context) lookupVar(name string) (float64, bool) {
+	if c == nil || c.vars == nil {
+		return 0, false
+	}
+	v, ok := c.vars[name]
+	return v, ok
+}
+
+// callFunc invokes the function registered under name with args,
+// returning an error if the function is unknown or called with the
+// wrong number of arguments.
+func (c *Context) callFunc(name string, args []float64) (float64, error) {
+	if c == nil || c.funcs == nil {
+		return 0, fmt.Errorf("%w: unknown function %q", ErrUnexpectedToken, name)
+	}
+	f, ok := c.funcs[name]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown function %q", ErrUnexpectedToken, name)
+	}
+	if len(args) != f.arity {
+		return 0, fmt.Errorf("%w: %s expects %d argument(s), got %d", ErrUnexpectedToken, name, f.arity, len(args))
+	}
+	return f.fn(args...)
+}
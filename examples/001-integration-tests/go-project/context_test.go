@@ -0,0 +1,110 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestEvaluateWithConstantsAndFunctions(t *testing.T) {
+	ctx := NewContext()
+	ctx.Set("r", 2)
+
+	tests := []struct {
+		name string
+		expr string
+		want float64
+	}{
+		{"constant", "pi", math.Pi},
+		{"user variable", "r * 2", 4},
+		{"function call", "sqrt(16)", 4},
+		{"nested function calls", "sum(1, 2, negative(3))", 0},
+		{"power operator", "2 ^ 3", 8},
+		{"right associative power", "2 ^ 3 ^ 2", 512},
+		{"mixed", "pi * r ^ 2", math.Pi * 4},
+	}
+
+	ctx.Register("sum", 3, func(args ...float64) (float64, error) {
+		total := 0.0
+		for _, a := range args {
+			total = Add(total, a)
+		}
+		return total, nil
+	})
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateWith(tt.expr, ctx)
+			if err != nil {
+				t.Fatalf("EvaluateWith(%q) returned error: %v", tt.expr, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EvaluateWith(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateWithErrors(t *testing.T) {
+	ctx := NewContext()
+
+	tests := []struct {
+		name string
+		expr string
+	}{
+		{"unknown variable", "doesnotexist"},
+		{"unknown function", "notafunc(1)"},
+		{"too many arguments", "sqrt(1, 2)"},
+		{"too few arguments", "sqrt()"},
+		{"wrong arity with trailing expression", "pi + sqrt()"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EvaluateWith(tt.expr, ctx); err == nil {
+				t.Fatalf("EvaluateWith(%q) returned no error", tt.expr)
+			}
+		})
+	}
+}
+
+func TestCallArityIsCheckedAgainstActualArgCount(t *testing.T) {
+	ctx := NewContext()
+
+	_, err := EvaluateWith("sqrt(1, 2)", ctx)
+	if !errors.Is(err, ErrUnexpectedToken) {
+		t.Fatalf("EvaluateWith(sqrt(1, 2)) error = %v, want ErrUnexpectedToken", err)
+	}
+	if !strings.Contains(err.Error(), "expects 1 argument") {
+		t.Errorf("EvaluateWith(sqrt(1, 2)) error = %v, want it to report the expected arity", err)
+	}
+}
+
+func TestBuiltins(t *testing.T) {
+	ctx := NewContext()
+
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"sqrt(9)", 3},
+		{"pow(2, 10)", 1024},
+		{"abs(-5)", 5},
+		{"min(3, 7)", 3},
+		{"max(3, 7)", 7},
+		{"negative(4)", -4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := EvaluateWith(tt.expr, ctx)
+			if err != nil {
+				t.Fatalf("EvaluateWith(%q) returned error: %v", tt.expr, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("EvaluateWith(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package calculator
+
+import "errors"
+
+// Sentinel errors returned (wrapped) by Evaluate and its helpers. Use
+// errors.Is to check for a specific failure.
+var (
+	// ErrDivByZero is returned when an expression divides by zero.
+	ErrDivByZero = errors.New("calculator: division by zero")
+	// ErrMismatchedParen is returned when parentheses in an expression
+	// don't balance.
+	ErrMismatchedParen = errors.New("calculator: mismatched parentheses")
+	// ErrUnexpectedToken is returned when the tokenizer or parser
+	// encounters a token it doesn't know how to handle.
+	ErrUnexpectedToken = errors.New("calculator: unexpected token")
+)
@@ -0,0 +1,185 @@
+package calculator
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// EvaluateNumber parses and computes expr like Evaluate, but dispatches
+// to the arithmetic backend selected by ctx.Mode() and returns a Number
+// rather than a float64. ModeRational and ModeBigFloat support only the
+// plain arithmetic grammar (+, -, *, /, unary minus, parentheses,
+// decimal literals); variables, function calls and "^" are a
+// ModeFloat64-only extension and report ErrUnexpectedToken in the other
+// modes.
+func EvaluateNumber(expr string, ctx *Context) (Number, error) {
+	if strings.TrimSpace(expr) == "" {
+		return Number{}, fmt.Errorf("%w: empty expression", ErrUnexpectedToken)
+	}
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return Number{}, err
+	}
+
+	rpn, err := toRPN(tokens)
+	if err != nil {
+		return Number{}, err
+	}
+
+	switch ctx.Mode() {
+	case ModeRational:
+		r, err := evalRPNRat(rpn)
+		if err != nil {
+			return Number{}, err
+		}
+		return newRatNumber(r), nil
+
+	case ModeBigFloat:
+		f, err := evalRPNBigFloat(rpn, ctx.Precision())
+		if err != nil {
+			return Number{}, err
+		}
+		return newBigFloatNumber(f), nil
+
+	default:
+		v, err := evalRPN(rpn, ctx)
+		if err != nil {
+			return Number{}, err
+		}
+		return newFloatNumber(v), nil
+	}
+}
+
+// evalRPNRat is the big.Rat counterpart of evalRPN.
+func evalRPNRat(rpn []token) (*big.Rat, error) {
+	var stack []*big.Rat
+
+	pop := func() (*big.Rat, error) {
+		if len(stack) == 0 {
+			return nil, fmt.Errorf("%w: missing operand", ErrUnexpectedToken)
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v, nil
+	}
+
+	for _, t := range rpn {
+		switch t.kind {
+		case tokNumber:
+			v, ok := new(big.Rat).SetString(t.text)
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			stack = append(stack, v)
+
+		case tokUMinus:
+			v, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, SubRat(new(big.Rat), v))
+
+		case tokOp:
+			b, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			a, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			switch t.text {
+			case "+":
+				stack = append(stack, AddRat(a, b))
+			case "-":
+				stack = append(stack, SubRat(a, b))
+			case "*":
+				stack = append(stack, MulRat(a, b))
+			case "/":
+				result, err := DivRat(a, b)
+				if err != nil {
+					return nil, err
+				}
+				stack = append(stack, result)
+			default:
+				return nil, fmt.Errorf("%w: %q is not supported in rational mode", ErrUnexpectedToken, t.text)
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: %q is not supported in rational mode", ErrUnexpectedToken, t.text)
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("%w: malformed expression", ErrUnexpectedToken)
+	}
+	return stack[0], nil
+}
+
+// evalRPNBigFloat is the big.Float counterpart of evalRPN, computing at
+// prec bits of precision.
+func evalRPNBigFloat(rpn []token, prec uint) (*big.Float, error) {
+	var stack []*big.Float
+
+	pop := func() (*big.Float, error) {
+		if len(stack) == 0 {
+			return nil, fmt.Errorf("%w: missing operand", ErrUnexpectedToken)
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v, nil
+	}
+
+	for _, t := range rpn {
+		switch t.kind {
+		case tokNumber:
+			v, ok := new(big.Float).SetPrec(prec).SetString(t.text)
+			if !ok {
+				return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			stack = append(stack, v)
+
+		case tokUMinus:
+			v, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			stack = append(stack, new(big.Float).SetPrec(prec).Neg(v))
+
+		case tokOp:
+			b, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			a, err := pop()
+			if err != nil {
+				return nil, err
+			}
+			switch t.text {
+			case "+":
+				stack = append(stack, new(big.Float).SetPrec(prec).Add(a, b))
+			case "-":
+				stack = append(stack, new(big.Float).SetPrec(prec).Sub(a, b))
+			case "*":
+				stack = append(stack, new(big.Float).SetPrec(prec).Mul(a, b))
+			case "/":
+				if b.Sign() == 0 {
+					return nil, ErrDivByZero
+				}
+				stack = append(stack, new(big.Float).SetPrec(prec).Quo(a, b))
+			default:
+				return nil, fmt.Errorf("%w: %q is not supported in big-float mode", ErrUnexpectedToken, t.text)
+			}
+
+		default:
+			return nil, fmt.Errorf("%w: %q is not supported in big-float mode", ErrUnexpectedToken, t.text)
+		}
+	}
+
+	if len(stack) != 1 {
+		return nil, fmt.Errorf("%w: malformed expression", ErrUnexpectedToken)
+	}
+	return stack[0], nil
+}
@@ -0,0 +1,349 @@
+package calculator
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// tokenKind identifies the kind of a scanned token.
+type tokenKind int
+
+const (
+	tokNumber tokenKind = iota
+	tokOp
+	tokLParen
+	tokRParen
+	tokUMinus
+	tokIdent
+	tokComma
+	tokFunc
+)
+
+// token is a single lexical unit produced by tokenize, annotated with the
+// rune offset it started at so errors can point back into the source.
+// argc is only meaningful on a tokFunc token: the number of
+// comma-separated arguments toRPN counted at the call site.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+	argc int
+}
+
+// tokenize scans expr into a flat token stream, skipping whitespace and
+// rewriting unary minus into a dedicated uminus token. A minus is unary
+// when it appears at the start of input, immediately after another
+// operator (including another unary minus), or immediately after "(" or
+// ",".
+func tokenize(expr string) ([]token, error) {
+	var tokens []token
+	runes := []rune(expr)
+
+	prevSignificant := func() *token {
+		if len(tokens) == 0 {
+			return nil
+		}
+		return &tokens[len(tokens)-1]
+	}
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+
+		case r == '(':
+			tokens = append(tokens, token{kind: tokLParen, text: "(", pos: i})
+			i++
+
+		case r == ')':
+			tokens = append(tokens, token{kind: tokRParen, text: ")", pos: i})
+			i++
+
+		case r == ',':
+			tokens = append(tokens, token{kind: tokComma, text: ",", pos: i})
+			i++
+
+		case r == '+' || r == '*' || r == '/' || r == '^':
+			tokens = append(tokens, token{kind: tokOp, text: string(r), pos: i})
+			i++
+
+		case r == '-':
+			prev := prevSignificant()
+			isUnary := prev == nil || prev.kind == tokOp || prev.kind == tokUMinus ||
+				prev.kind == tokLParen || prev.kind == tokComma
+			if isUnary {
+				tokens = append(tokens, token{kind: tokUMinus, text: "-", pos: i})
+			} else {
+				tokens = append(tokens, token{kind: tokOp, text: "-", pos: i})
+			}
+			i++
+
+		case unicode.IsDigit(r) || r == '.':
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokNumber, text: string(runes[start:i]), pos: start})
+
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokIdent, text: string(runes[start:i]), pos: start})
+
+		default:
+			return nil, fmt.Errorf("%w: unknown character %q at position %d", ErrUnexpectedToken, r, i)
+		}
+	}
+
+	return tokens, nil
+}
+
+// precedence maps a binary operator to its precedence used by the
+// shunting-yard conversion below. All operators are left-associative
+// except "^", which is right-associative (see rightAssoc).
+var precedence = map[string]int{
+	"+": 1,
+	"-": 1,
+	"*": 2,
+	"/": 2,
+	"^": 3,
+}
+
+// rightAssoc lists operators that associate right-to-left, so that
+// "2 ^ 3 ^ 2" parses as "2 ^ (3 ^ 2)".
+var rightAssoc = map[string]bool{
+	"^": true,
+}
+
+// callFrame tracks the comma-separated argument count for one open
+// paren, so a matching tokFunc can be emitted with the actual number of
+// arguments it was called with (not just its registered arity).
+type callFrame struct {
+	isCall bool
+	empty  bool
+	commas int
+}
+
+// toRPN converts an infix token stream to reverse Polish notation using
+// the shunting-yard algorithm. tokUMinus is treated as a unary operator
+// that binds tighter than any binary operator. An identifier immediately
+// followed by "(" is treated as a function call and emitted as tokFunc
+// carrying its actual argument count; any other identifier is emitted as
+// a bare variable reference.
+func toRPN(tokens []token) ([]token, error) {
+	var output []token
+	var ops []token
+	var frames []callFrame
+
+	popWhile := func(keep func(top token) bool) {
+		for len(ops) > 0 && keep(ops[len(ops)-1]) {
+			output = append(output, ops[len(ops)-1])
+			ops = ops[:len(ops)-1]
+		}
+	}
+
+	for i := 0; i < len(tokens); i++ {
+		t := tokens[i]
+		switch t.kind {
+		case tokNumber:
+			output = append(output, t)
+
+		case tokIdent:
+			if i+1 < len(tokens) && tokens[i+1].kind == tokLParen {
+				ops = append(ops, token{kind: tokFunc, text: t.text, pos: t.pos})
+			} else {
+				output = append(output, t)
+			}
+
+		case tokUMinus:
+			ops = append(ops, t)
+
+		case tokOp:
+			prec := precedence[t.text]
+			popWhile(func(top token) bool {
+				if top.kind == tokUMinus {
+					return true
+				}
+				if top.kind != tokOp {
+					return false
+				}
+				if rightAssoc[t.text] {
+					return precedence[top.text] > prec
+				}
+				return precedence[top.text] >= prec
+			})
+			ops = append(ops, t)
+
+		case tokLParen:
+			frames = append(frames, callFrame{
+				isCall: len(ops) > 0 && ops[len(ops)-1].kind == tokFunc,
+				empty:  i+1 < len(tokens) && tokens[i+1].kind == tokRParen,
+			})
+			ops = append(ops, t)
+
+		case tokComma:
+			popWhile(func(top token) bool { return top.kind != tokLParen })
+			if len(ops) == 0 {
+				return nil, fmt.Errorf("%w: misplaced ',' at position %d", ErrUnexpectedToken, t.pos)
+			}
+			if len(frames) > 0 && frames[len(frames)-1].isCall {
+				frames[len(frames)-1].commas++
+			}
+
+		case tokRParen:
+			popWhile(func(top token) bool { return top.kind != tokLParen })
+			if len(ops) == 0 {
+				return nil, fmt.Errorf("%w: unmatched ')' at position %d", ErrMismatchedParen, t.pos)
+			}
+			ops = ops[:len(ops)-1] // discard the matching "("
+
+			var frame callFrame
+			if len(frames) > 0 {
+				frame = frames[len(frames)-1]
+				frames = frames[:len(frames)-1]
+			}
+			if len(ops) > 0 && ops[len(ops)-1].kind == tokFunc {
+				fn := ops[len(ops)-1]
+				ops = ops[:len(ops)-1]
+				if !frame.empty {
+					fn.argc = frame.commas + 1
+				}
+				output = append(output, fn)
+			}
+		}
+	}
+
+	popWhile(func(top token) bool {
+		if top.kind == tokLParen {
+			return false
+		}
+		return true
+	})
+	for _, t := range ops {
+		if t.kind == tokLParen {
+			return nil, fmt.Errorf("%w: unmatched '(' at position %d", ErrMismatchedParen, t.pos)
+		}
+	}
+
+	return output, nil
+}
+
+// evalRPN walks a reverse-Polish token stream, pushing numbers onto a
+// stack and applying the DefaultOps entry for every binary operator it
+// pops (Add/Subtract/Multiply/Divide for the four basic operators,
+// math.Pow for "^"). Variables and function calls are resolved against
+// ctx.
+func evalRPN(rpn []token, ctx *Context) (float64, error) {
+	var stack []float64
+
+	pop := func() (float64, error) {
+		if len(stack) == 0 {
+			return 0, fmt.Errorf("%w: missing operand", ErrUnexpectedToken)
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v, nil
+	}
+
+	for _, t := range rpn {
+		switch t.kind {
+		case tokNumber:
+			v, err := strconv.ParseFloat(t.text, 64)
+			if err != nil {
+				return 0, fmt.Errorf("%w: invalid number %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			stack = append(stack, v)
+
+		case tokIdent:
+			v, ok := ctx.lookupVar(t.text)
+			if !ok {
+				return 0, fmt.Errorf("%w: unknown variable %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			stack = append(stack, v)
+
+		case tokFunc:
+			if len(stack) < t.argc {
+				return 0, fmt.Errorf("%w: not enough arguments for %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			args := append([]float64(nil), stack[len(stack)-t.argc:]...)
+			stack = stack[:len(stack)-t.argc]
+			result, err := ctx.callFunc(t.text, args)
+			if err != nil {
+				return 0, fmt.Errorf("%w (at position %d)", err, t.pos)
+			}
+			stack = append(stack, result)
+
+		case tokUMinus:
+			v, err := pop()
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack, Subtract(0, v))
+
+		case tokOp:
+			b, err := pop()
+			if err != nil {
+				return 0, err
+			}
+			a, err := pop()
+			if err != nil {
+				return 0, err
+			}
+			op, ok := DefaultOps[t.text]
+			if !ok {
+				return 0, fmt.Errorf("%w: unknown operator %q at position %d", ErrUnexpectedToken, t.text, t.pos)
+			}
+			result, err := op(a, b)
+			if err != nil {
+				return 0, err
+			}
+			stack = append(stack, result)
+
+		default:
+			return 0, fmt.Errorf("%w: unexpected token %q", ErrUnexpectedToken, t.text)
+		}
+	}
+
+	if len(stack) != 1 {
+		return 0, fmt.Errorf("%w: malformed expression", ErrUnexpectedToken)
+	}
+	return stack[0], nil
+}
+
+// Evaluate parses and computes a full infix arithmetic expression such as
+// "100 + ((2 / 4) * 2 * 3)", reusing Add, Subtract, Multiply and Divide
+// for +, -, * and /; "^" uses math.Pow. Supported operators are +, -, *, /, ^ with
+// the usual precedence (^ is right-associative), unary minus,
+// parenthesised sub-expressions, and decimal literals. It is equivalent
+// to EvaluateWith(expr, NewContext()), so pi, e and the built-in
+// functions are available.
+func Evaluate(expr string) (float64, error) {
+	return EvaluateWith(expr, NewContext())
+}
+
+// EvaluateWith parses and computes expr like Evaluate, additionally
+// resolving identifiers against ctx: a bare identifier is looked up as a
+// variable, and an identifier followed by "(" is looked up as a
+// function, e.g. "sum(1, 2, negative(3)) + pi*r^2".
+func EvaluateWith(expr string, ctx *Context) (float64, error) {
+	if strings.TrimSpace(expr) == "" {
+		return 0, fmt.Errorf("%w: empty expression", ErrUnexpectedToken)
+	}
+
+	tokens, err := tokenize(expr)
+	if err != nil {
+		return 0, err
+	}
+
+	rpn, err := toRPN(tokens)
+	if err != nil {
+		return 0, err
+	}
+
+	return evalRPN(rpn, ctx)
+}
@@ -0,0 +1,62 @@
+package calculator
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEvaluate(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want float64
+	}{
+		{"simple addition", "1 + 2", 3},
+		{"precedence", "2 + 3 * 4", 14},
+		{"nested parens", "100 + ((2 / 4) * 2 * 3)", 103},
+		{"left associative subtraction", "10 - 2 - 3", 5},
+		{"unary minus", "-5 + 3", -2},
+		{"unary minus after paren", "3 * (-2 + 1)", -3},
+		{"decimal literals", "1.5 + 2.25", 3.75},
+		{"whitespace insensitive", "  1+2 *3 ", 7},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Evaluate(tt.expr)
+			if err != nil {
+				t.Fatalf("Evaluate(%q) returned error: %v", tt.expr, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("Evaluate(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEvaluateErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		expr string
+		want error
+	}{
+		{"division by zero", "1 / 0", ErrDivByZero},
+		{"unmatched open paren", "(1 + 2", ErrMismatchedParen},
+		{"unmatched close paren", "1 + 2)", ErrMismatchedParen},
+		{"unknown character", "1 + @", ErrUnexpectedToken},
+		{"empty expression", "", ErrUnexpectedToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Evaluate(tt.expr)
+			if err == nil {
+				t.Fatalf("Evaluate(%q) returned no error, want %v", tt.expr, tt.want)
+			}
+			if !errors.Is(err, tt.want) {
+				t.Errorf("Evaluate(%q) error = %v, want errors.Is(%v)", tt.expr, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package calculator
+
+import (
+	"math/big"
+	"strconv"
+)
+
+// Number is the result of EvaluateNumber: a sum type over the three
+// arithmetic backends, tagged by the Mode it was produced in.
+type Number struct {
+	mode Mode
+	f    float64
+	rat  *big.Rat
+	bf   *big.Float
+}
+
+func newFloatNumber(f float64) Number {
+	return Number{mode: ModeFloat64, f: f}
+}
+
+func newRatNumber(r *big.Rat) Number {
+	return Number{mode: ModeRational, rat: r}
+}
+
+func newBigFloatNumber(f *big.Float) Number {
+	return Number{mode: ModeBigFloat, bf: f}
+}
+
+// String renders the number using the natural format for its backend:
+// a plain float for ModeFloat64, an exact "num/den" or integer for
+// ModeRational, and a decimal string at its working precision for
+// ModeBigFloat.
+func (n Number) String() string {
+	switch n.mode {
+	case ModeRational:
+		return n.rat.RatString()
+	case ModeBigFloat:
+		return n.bf.Text('g', -1)
+	default:
+		return strconv.FormatFloat(n.f, 'g', -1, 64)
+	}
+}
+
+// Float64 returns the number as a float64, rounding if it came from a
+// higher-precision backend.
+func (n Number) Float64() float64 {
+	switch n.mode {
+	case ModeRational:
+		f, _ := n.rat.Float64()
+		return f
+	case ModeBigFloat:
+		f, _ := n.bf.Float64()
+		return f
+	default:
+		return n.f
+	}
+}
+
+// Rat returns the number as an exact *big.Rat, converting from whichever
+// backend produced it.
+func (n Number) Rat() *big.Rat {
+	switch n.mode {
+	case ModeRational:
+		return n.rat
+	case ModeBigFloat:
+		r, _ := n.bf.Rat(nil)
+		return r
+	default:
+		return new(big.Rat).SetFloat64(n.f)
+	}
+}
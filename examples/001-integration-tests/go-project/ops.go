@@ -0,0 +1,27 @@
+package calculator
+
+import (
+	"fmt"
+	"math"
+)
+
+// BinaryOp computes a two-operand operator such as "+" or "*". It is the
+// shared shape used by both the infix evaluator and calculator/rpn, so a
+// caller can plug a custom operator table into either.
+type BinaryOp func(a, b float64) (float64, error)
+
+// DefaultOps is the standard operator table: +, -, * and / backed by
+// Add, Subtract, Multiply and Divide, and ^ backed by math.Pow.
+var DefaultOps = map[string]BinaryOp{
+	"+": func(a, b float64) (float64, error) { return Add(a, b), nil },
+	"-": func(a, b float64) (float64, error) { return Subtract(a, b), nil },
+	"*": func(a, b float64) (float64, error) { return Multiply(a, b), nil },
+	"/": func(a, b float64) (float64, error) {
+		result, err := Divide(a, b)
+		if err != nil {
+			return 0, fmt.Errorf("%w: %v", ErrDivByZero, err)
+		}
+		return result, nil
+	},
+	"^": func(a, b float64) (float64, error) { return math.Pow(a, b), nil },
+}
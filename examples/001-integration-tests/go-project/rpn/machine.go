@@ -0,0 +1,78 @@
+package rpn
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	calculator "github.com/sanketn26/vivek/examples/001-integration-tests/go-project"
+)
+
+// Machine evaluates postfix expressions one token at a time against an
+// internal Stack. Its Ops table is calculator.DefaultOps by default, so
+// it shares operator behavior (and leaf arithmetic) with the infix
+// evaluator; replace it to support a different operator set.
+type Machine struct {
+	stack *Stack
+	Ops   map[string]calculator.BinaryOp
+}
+
+// NewMachine returns a Machine with an empty Stack and the default
+// operator table.
+func NewMachine() *Machine {
+	return &Machine{stack: NewStack(), Ops: calculator.DefaultOps}
+}
+
+// Stack returns the machine's underlying Stack so callers can inspect it
+// between Step calls.
+func (m *Machine) Stack() *Stack {
+	return m.stack
+}
+
+// Step consumes a single RPN token: a number is pushed, an operator pops
+// its two operands, applies Ops[token], and pushes the result. A failed
+// Step (too few operands, or the operator itself erroring) leaves the
+// stack exactly as it was before the call.
+func (m *Machine) Step(token string) error {
+	if op, ok := m.Ops[token]; ok {
+		if m.stack.Len() < 2 {
+			return fmt.Errorf("rpn: %q: not enough operands", token)
+		}
+		b, _ := m.stack.Pop()
+		a, _ := m.stack.Pop()
+		result, err := op(a, b)
+		if err != nil {
+			m.stack.Push(a)
+			m.stack.Push(b)
+			return err
+		}
+		m.stack.Push(result)
+		return nil
+	}
+
+	v, err := strconv.ParseFloat(token, 64)
+	if err != nil {
+		return fmt.Errorf("rpn: invalid token %q", token)
+	}
+	m.stack.Push(v)
+	return nil
+}
+
+// Eval clears the stack and evaluates a whitespace-separated RPN
+// expression such as "5 1 2 + 4 * + 3 -", returning the final top of
+// stack.
+func (m *Machine) Eval(expr string) (float64, error) {
+	m.stack.Clear()
+
+	for _, tok := range strings.Fields(expr) {
+		if err := m.Step(tok); err != nil {
+			return 0, err
+		}
+	}
+
+	v, ok := m.stack.Peek()
+	if !ok || m.stack.Len() != 1 {
+		return 0, fmt.Errorf("rpn: malformed expression %q", expr)
+	}
+	return v, nil
+}
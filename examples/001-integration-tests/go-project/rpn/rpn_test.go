@@ -0,0 +1,126 @@
+package rpn
+
+import (
+	"testing"
+)
+
+func TestStack(t *testing.T) {
+	s := NewStack()
+	if s.Len() != 0 {
+		t.Fatalf("new stack Len() = %d, want 0", s.Len())
+	}
+
+	s.Push(1)
+	s.Push(2)
+	if s.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", s.Len())
+	}
+
+	if v, ok := s.Peek(); !ok || v != 2 {
+		t.Errorf("Peek() = %v, %v; want 2, true", v, ok)
+	}
+
+	if v, ok := s.Pop(); !ok || v != 2 {
+		t.Errorf("Pop() = %v, %v; want 2, true", v, ok)
+	}
+	if v, ok := s.Pop(); !ok || v != 1 {
+		t.Errorf("Pop() = %v, %v; want 1, true", v, ok)
+	}
+	if _, ok := s.Pop(); ok {
+		t.Errorf("Pop() on empty stack returned ok = true")
+	}
+
+	s.Push(5)
+	s.Clear()
+	if s.Len() != 0 {
+		t.Errorf("Len() after Clear() = %d, want 0", s.Len())
+	}
+}
+
+func TestMachineEval(t *testing.T) {
+	tests := []struct {
+		expr string
+		want float64
+	}{
+		{"3 4 +", 7},
+		{"5 1 2 + 4 * + 3 -", 14},
+		{"10 2 /", 5},
+		{"2 3 ^", 8},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			m := NewMachine()
+			got, err := m.Eval(tt.expr)
+			if err != nil {
+				t.Fatalf("Eval(%q) returned error: %v", tt.expr, err)
+			}
+			if got != tt.want {
+				t.Errorf("Eval(%q) = %v, want %v", tt.expr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMachineStep(t *testing.T) {
+	m := NewMachine()
+	for _, tok := range []string{"3", "4"} {
+		if err := m.Step(tok); err != nil {
+			t.Fatalf("Step(%q) returned error: %v", tok, err)
+		}
+	}
+	if m.Stack().Len() != 2 {
+		t.Fatalf("Stack().Len() = %d, want 2", m.Stack().Len())
+	}
+
+	if err := m.Step("+"); err != nil {
+		t.Fatalf("Step(\"+\") returned error: %v", err)
+	}
+	v, ok := m.Stack().Peek()
+	if !ok || v != 7 {
+		t.Errorf("Stack top = %v, %v; want 7, true", v, ok)
+	}
+}
+
+func TestMachineDivByZero(t *testing.T) {
+	m := NewMachine()
+	if _, err := m.Eval("1 0 /"); err == nil {
+		t.Fatal("Eval(\"1 0 /\") returned no error")
+	}
+}
+
+func TestMachineStepUnderflowLeavesStackUntouched(t *testing.T) {
+	m := NewMachine()
+	if err := m.Step("5"); err != nil {
+		t.Fatalf("Step(\"5\") returned error: %v", err)
+	}
+
+	if err := m.Step("+"); err == nil {
+		t.Fatal("Step(\"+\") on a one-element stack returned no error")
+	}
+
+	if m.Stack().Len() != 1 {
+		t.Fatalf("Stack().Len() after failed Step = %d, want 1", m.Stack().Len())
+	}
+	v, ok := m.Stack().Peek()
+	if !ok || v != 5 {
+		t.Errorf("Stack top after failed Step = %v, %v; want 5, true", v, ok)
+	}
+}
+
+func TestMachineStepOperatorErrorLeavesStackUntouched(t *testing.T) {
+	m := NewMachine()
+	for _, tok := range []string{"1", "0"} {
+		if err := m.Step(tok); err != nil {
+			t.Fatalf("Step(%q) returned error: %v", tok, err)
+		}
+	}
+
+	if err := m.Step("/"); err == nil {
+		t.Fatal("Step(\"/\") on \"1 0\" returned no error")
+	}
+
+	if m.Stack().Len() != 2 {
+		t.Fatalf("Stack().Len() after failed Step = %d, want 2", m.Stack().Len())
+	}
+}
@@ -0,0 +1,48 @@
+// Package rpn implements a postfix (reverse Polish notation) calculator
+// built on a simple float64 stack machine.
+package rpn
+
+// Stack is a LIFO stack of float64 values.
+type Stack struct {
+	data []float64
+}
+
+// NewStack returns an empty Stack.
+func NewStack() *Stack {
+	return &Stack{}
+}
+
+// Push adds v to the top of the stack.
+func (s *Stack) Push(v float64) {
+	s.data = append(s.data, v)
+}
+
+// Pop removes and returns the top of the stack. ok is false if the
+// stack is empty.
+func (s *Stack) Pop() (v float64, ok bool) {
+	if len(s.data) == 0 {
+		return 0, false
+	}
+	v = s.data[len(s.data)-1]
+	s.data = s.data[:len(s.data)-1]
+	return v, true
+}
+
+// Peek returns the top of the stack without removing it. ok is false if
+// the stack is empty.
+func (s *Stack) Peek() (v float64, ok bool) {
+	if len(s.data) == 0 {
+		return 0, false
+	}
+	return s.data[len(s.data)-1], true
+}
+
+// Len returns the number of values on the stack.
+func (s *Stack) Len() int {
+	return len(s.data)
+}
+
+// Clear empties the stack.
+func (s *Stack) Clear() {
+	s.data = s.data[:0]
+}